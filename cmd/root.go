@@ -11,28 +11,45 @@ import (
 
 func Root() *cobra.Command {
 	var (
-		verbose bool
-		timeout int
-		refresh bool
+		verbose  bool
+		timeout  int
+		refresh  bool
+		proxy    string
+		insecure bool
+		major    int
 	)
 
 	root := &cobra.Command{
-		Use:   "go-get [module]",
-		Short: "Automatically get the latest compatible version of a Go module",
+		Use:   "go-get [module...]",
+		Short: "Automatically get the latest compatible version of one or more Go modules",
 		Long: `A tool that determines the latest version of a Go module compatible with your current Go version and runs 'go get' for you.
 
 go-get will automatically:
 - Detect your local Go version
-- Find all available versions of the requested module
+- Find all available versions of the requested module(s)
 - Filter out pre-release and incompatible versions
 - Select the latest version compatible with your Go version
-- Run 'go get' with the selected version
+- Run 'go get' with the selected version(s)
 
-This ensures you always get the most recent version that will actually work with your Go installation.
+When given multiple modules, go-get resolves them concurrently and installs
+them all with a single 'go get' invocation, so the module graph is solved
+once instead of once per module.
+
+A module may carry a version constraint after "@", the same way 'go get'
+itself accepts a version suffix: an exact version, "latest", a caret range
+("^1.9"), a tilde range ("~1.9.2"), an x-range ("1.x"), or a comma-separated
+comparator list ("` + ">=1.9,<2" + `"). go-get picks the newest version
+satisfying the constraint that's also compatible with your Go version.
 `,
 		Example: `  # Get the latest compatible version of a module
   go-get github.com/gin-gonic/gin
 
+  # Resolve several modules concurrently and install them together
+  go-get github.com/gin-gonic/gin github.com/spf13/cobra
+
+  # Constrain to a semver range
+  go-get github.com/gin-gonic/gin@^1.9
+
   # Force refresh the cache for the latest information
   go-get -r github.com/gin-gonic/gin
 
@@ -40,8 +57,7 @@ This ensures you always get the most recent version that will actually work with
   go-get -v github.com/gin-gonic/gin`,
 		Args: cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			m := mod.NewManager(verbose)
-			module := args[0]
+			m := mod.NewManager(verbose, proxy, insecure)
 
 			var timer *time.Timer
 			if timeout > 0 {
@@ -51,15 +67,18 @@ This ensures you always get the most recent version that will actually work with
 				})
 			}
 
-			err := m.GoGet(module, refresh)
+			results := m.BatchGet(args, refresh, major)
 
 			if timer != nil {
 				timer.Stop()
 			}
 
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+			printResults(args, results)
+
+			for _, module := range args {
+				if results[module] != nil {
+					os.Exit(1)
+				}
 			}
 		},
 	}
@@ -67,6 +86,25 @@ This ensures you always get the most recent version that will actually work with
 	root.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging to show detailed progress")
 	root.Flags().IntVarP(&timeout, "timeout", "t", 60, "Set global timeout in seconds (0 means no timeout)")
 	root.Flags().BoolVarP(&refresh, "refresh", "r", false, "Force refresh cache and fetch the latest module information")
+	root.Flags().StringVar(&proxy, "proxy", "", "GOPROXY-style proxy list to use instead of the GOPROXY environment variable (supports \",\"/\"|\" fallback and the direct/off pseudo-entries)")
+	root.Flags().BoolVar(&insecure, "insecure", false, "Skip GOSUMDB checksum verification entirely")
+	root.Flags().IntVar(&major, "major", 0, "Major version to resolve (2 or above rewrites each module's path with a /vN suffix, per Go's module path convention)")
+
+	root.AddCommand(newTidyCmd())
 
 	return root
 }
+
+// printResults renders a per-module success/failure table in the order the
+// modules were requested.
+func printResults(modules []string, results map[string]error) {
+	fmt.Println()
+	fmt.Println("MODULE                                              STATUS")
+	for _, module := range modules {
+		if err := results[module]; err != nil {
+			fmt.Printf("%-50s  FAILED: %v\n", module, err)
+		} else {
+			fmt.Printf("%-50s  OK\n", module)
+		}
+	}
+}
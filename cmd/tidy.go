@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/liuyuxuan0723/go-get/pkg/mod"
+	"github.com/spf13/cobra"
+)
+
+func newTidyCmd() *cobra.Command {
+	var (
+		verbose  bool
+		proxy    string
+		insecure bool
+		dryRun   bool
+	)
+
+	tidy := &cobra.Command{
+		Use:   "tidy",
+		Short: "Add missing and remove unused requirements in go.mod/go.sum",
+		Long: `tidy analyzes the Go source of the module in the current directory,
+computes the set of modules it actually imports via go/packages, resolves
+versions for any that go.mod doesn't already require, and runs the same
+Minimum Version Selection logic 'go-get' uses for a single module over the
+result to get the full require list. It then rewrites go.mod and go.sum to
+match, atomically.
+`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			m := mod.NewManager(verbose, proxy, insecure)
+
+			result, err := m.AnalyzeTidy(".")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "go-get tidy: %v\n", err)
+				os.Exit(1)
+			}
+
+			if dryRun {
+				printTidyDiff(result)
+				return
+			}
+
+			if err := m.ApplyTidy(result); err != nil {
+				fmt.Fprintf(os.Stderr, "go-get tidy: %v\n", err)
+				os.Exit(1)
+			}
+
+			for _, v := range result.Added {
+				fmt.Printf("added %s %s\n", v.Path, v.Version)
+			}
+			for _, v := range result.Removed {
+				fmt.Printf("removed %s %s\n", v.Path, v.Version)
+			}
+		},
+	}
+
+	tidy.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging to show detailed progress")
+	tidy.Flags().StringVar(&proxy, "proxy", "", "GOPROXY-style proxy list to use instead of the GOPROXY environment variable")
+	tidy.Flags().BoolVar(&insecure, "insecure", false, "Skip GOSUMDB checksum verification entirely")
+	tidy.Flags().BoolVar(&dryRun, "dry-run", false, "Print a unified diff of the proposed go.mod/go.sum changes without writing them")
+
+	return tidy
+}
+
+// printTidyDiff prints a unified diff of the go.mod/go.sum changes result
+// describes, without writing anything to disk.
+func printTidyDiff(result *mod.TidyResult) {
+	if diff := unifiedDiff(result.GoModPath, result.OldGoMod, result.NewGoMod); diff != "" {
+		fmt.Print(diff)
+	}
+	if diff := unifiedDiff(result.GoSumPath, result.OldGoSum, result.NewGoSum); diff != "" {
+		fmt.Print(diff)
+	}
+}
+
+// diffOp is one line of a computed edit script: kept as-is, removed from
+// the old file, or added in the new one.
+type diffOp struct {
+	kind diffKind
+	line string
+}
+
+type diffKind int
+
+const (
+	diffEqual diffKind = iota
+	diffDelete
+	diffInsert
+)
+
+// unifiedDiff renders a unified diff between old and new, labeled with
+// path, or "" if they're identical. Unlike 'diff -u' it doesn't trim
+// unchanged context out of the hunk; go.mod/go.sum are small enough that
+// showing the whole file is clearer than chasing hunk boundaries.
+func unifiedDiff(path string, old, new []byte) string {
+	oldLines := splitLines(old)
+	newLines := splitLines(new)
+
+	ops := diffLines(oldLines, newLines)
+
+	var changed bool
+	for _, op := range ops {
+		if op.kind != diffEqual {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%s\n", path)
+	fmt.Fprintf(&b, "+++ b/%s\n", path)
+	fmt.Fprintf(&b, "@@ -1,%d +1,%d @@\n", len(oldLines), len(newLines))
+	for _, op := range ops {
+		switch op.kind {
+		case diffEqual:
+			fmt.Fprintf(&b, " %s\n", op.line)
+		case diffDelete:
+			fmt.Fprintf(&b, "-%s\n", op.line)
+		case diffInsert:
+			fmt.Fprintf(&b, "+%s\n", op.line)
+		}
+	}
+
+	return b.String()
+}
+
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+// diffLines computes a minimal line-level edit script from a to b via the
+// standard longest-common-subsequence dynamic program.
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{diffEqual, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{diffDelete, a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{diffInsert, b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{diffDelete, a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{diffInsert, b[j]})
+	}
+
+	return ops
+}
@@ -4,11 +4,10 @@ import (
 	"os"
 
 	"github.com/liuyuxuan0723/go-get/cmd"
-	"github.com/liuyuxuan0723/go-get/pkg/mod"
 )
 
 func main() {
-	root := cmd.Root(mod.NewManager())
+	root := cmd.Root()
 	if err := root.Execute(); err != nil {
 		os.Exit(1)
 	}
@@ -0,0 +1,172 @@
+package mod
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// ModuleSpec is a "module@constraint" CLI argument split into its module
+// path and semver constraint, with --major's /vN rewrite already applied.
+type ModuleSpec struct {
+	// Path is the module path to resolve, e.g. "github.com/gin-gonic/gin".
+	Path string
+	// Constraint is the text following "@", or "" when the argument named
+	// only a bare module path or "@latest" (pick the newest compatible
+	// version, same as today's default behavior).
+	Constraint string
+}
+
+// parseModuleSpec splits a "module@constraint" CLI argument into a
+// ModuleSpec, rewriting Path with a /vN suffix when major is 2 or above,
+// per Go's major-version-in-module-path convention.
+func parseModuleSpec(arg string, major int) ModuleSpec {
+	path, constraint, _ := strings.Cut(arg, "@")
+	if constraint == "latest" {
+		constraint = ""
+	}
+
+	if major >= 2 {
+		path = rewriteMajorPath(path, major)
+	}
+
+	return ModuleSpec{Path: path, Constraint: constraint}
+}
+
+var majorSuffixPattern = regexp.MustCompile(`/v\d+$`)
+
+// rewriteMajorPath replaces path's existing /vN suffix, if any, with
+// /v<major>, or appends one when path has none.
+func rewriteMajorPath(path string, major int) string {
+	base := majorSuffixPattern.ReplaceAllString(path, "")
+	return fmt.Sprintf("%s/v%d", base, major)
+}
+
+// cacheKey is the key s's resolved version is cached under: the bare
+// module path when there's no constraint (matching pre-constraint cache
+// entries), or "path@constraint" so different constraints on the same
+// module don't clobber each other.
+func (s ModuleSpec) cacheKey() string {
+	if s.Constraint == "" {
+		return s.Path
+	}
+	return s.Path + "@" + s.Constraint
+}
+
+// constraints parses s.Constraint into a hashicorp/go-version Constraints,
+// translating the shorthand this tool accepts on the CLI (^, ~, N.x) into
+// the comparator syntax go-version understands. It returns nil, nil when
+// s.Constraint is empty.
+func (s ModuleSpec) constraints() (version.Constraints, error) {
+	if s.Constraint == "" {
+		return nil, nil
+	}
+
+	translated, err := translateConstraint(s.Constraint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid constraint %q for %s: %w", s.Constraint, s.Path, err)
+	}
+
+	c, err := version.NewConstraint(translated)
+	if err != nil {
+		return nil, fmt.Errorf("invalid constraint %q for %s: %w", s.Constraint, s.Path, err)
+	}
+
+	return c, nil
+}
+
+var xRangePattern = regexp.MustCompile(`^(\d+)\.[xX*]$`)
+
+// translateConstraint rewrites the npm-style shorthands this tool accepts
+// (^1.9 caret ranges, ~1.9.2 tilde ranges, 1.x x-ranges) into a
+// comma-separated list of hashicorp/go-version comparators, and passes
+// already-valid comparator expressions (">=1.9,<2") straight through.
+func translateConstraint(spec string) (string, error) {
+	spec = strings.TrimSpace(spec)
+
+	switch {
+	case strings.HasPrefix(spec, "^"):
+		return caretRange(strings.TrimPrefix(spec, "^"))
+	case strings.HasPrefix(spec, "~"):
+		return tildeRange(strings.TrimPrefix(spec, "~"))
+	case xRangePattern.MatchString(spec):
+		m := xRangePattern.FindStringSubmatch(spec)
+		major, _ := strconv.Atoi(m[1])
+		return fmt.Sprintf(">= %d.0.0, < %d.0.0", major, major+1), nil
+	default:
+		return spec, nil
+	}
+}
+
+// caretRange translates "^v" into the widest range that npm's caret
+// operator allows: patch and minor upgrades, but not the next major (or,
+// for a 0.x version, not the next minor).
+func caretRange(v string) (string, error) {
+	parts, err := versionParts(v)
+	if err != nil {
+		return "", err
+	}
+
+	var upper string
+	switch {
+	case parts[0] != 0:
+		upper = fmt.Sprintf("%d.0.0", parts[0]+1)
+	case parts[1] != 0:
+		upper = fmt.Sprintf("0.%d.0", parts[1]+1)
+	default:
+		upper = fmt.Sprintf("0.0.%d", parts[2]+1)
+	}
+
+	return fmt.Sprintf(">= %s, < %s", v, upper), nil
+}
+
+// tildeRange translates "~v" into npm's tilde range: patch-level upgrades
+// only, within the given major.minor.
+func tildeRange(v string) (string, error) {
+	parts, err := versionParts(v)
+	if err != nil {
+		return "", err
+	}
+
+	upper := fmt.Sprintf("%d.%d.0", parts[0], parts[1]+1)
+	return fmt.Sprintf(">= %s, < %s", v, upper), nil
+}
+
+// versionParts pads a "major", "major.minor" or "major.minor.patch"
+// version string out to three integer components.
+func versionParts(v string) ([3]int, error) {
+	var parts [3]int
+	for i, f := range strings.SplitN(v, ".", 3) {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return parts, fmt.Errorf("invalid version %q in constraint", v)
+		}
+		parts[i] = n
+	}
+	return parts, nil
+}
+
+// filterByConstraints returns the subset of versions satisfying
+// constraints, preserving order. A nil constraints (no "@constraint" was
+// given) returns versions unchanged.
+func filterByConstraints(versions []string, constraints version.Constraints) []string {
+	if constraints == nil {
+		return versions
+	}
+
+	var filtered []string
+	for _, v := range versions {
+		parsed, err := version.NewVersion(v)
+		if err != nil {
+			continue
+		}
+		if constraints.Check(parsed) {
+			filtered = append(filtered, v)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,85 @@
+package mod
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseModuleSpec(t *testing.T) {
+	tests := []struct {
+		name  string
+		arg   string
+		major int
+		want  ModuleSpec
+	}{
+		{"bare path", "github.com/gin-gonic/gin", 0, ModuleSpec{Path: "github.com/gin-gonic/gin"}},
+		{"explicit latest", "github.com/gin-gonic/gin@latest", 0, ModuleSpec{Path: "github.com/gin-gonic/gin"}},
+		{"constraint", "github.com/gin-gonic/gin@^1.9", 0, ModuleSpec{Path: "github.com/gin-gonic/gin", Constraint: "^1.9"}},
+		{"major rewrite appends", "github.com/gin-gonic/gin@^2.0", 2, ModuleSpec{Path: "github.com/gin-gonic/gin/v2", Constraint: "^2.0"}},
+		{"major rewrite replaces", "github.com/gin-gonic/gin/v1@^3.0", 3, ModuleSpec{Path: "github.com/gin-gonic/gin/v3", Constraint: "^3.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseModuleSpec(tt.arg, tt.major)
+			if got != tt.want {
+				t.Errorf("parseModuleSpec(%q, %d) = %+v, want %+v", tt.arg, tt.major, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslateConstraint(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    string
+		wantErr bool
+	}{
+		{"^1.9", ">= 1.9, < 2.0.0", false},
+		{"^1.9.2", ">= 1.9.2, < 2.0.0", false},
+		{"^0.9.2", ">= 0.9.2, < 0.10.0", false},
+		{"^0.0.2", ">= 0.0.2, < 0.0.3", false},
+		{"~1.9.2", ">= 1.9.2, < 1.10.0", false},
+		{"1.x", ">= 1.0.0, < 2.0.0", false},
+		{"2.X", ">= 2.0.0, < 3.0.0", false},
+		{">=1.9,<2", ">=1.9,<2", false},
+		{"^abc", "", true},
+		{"~abc", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := translateConstraint(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("translateConstraint(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("translateConstraint(%q) = %q, want %q", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterByConstraints(t *testing.T) {
+	spec := ModuleSpec{Path: "example.com/mod", Constraint: "^1.9"}
+	constraints, err := spec.constraints()
+	if err != nil {
+		t.Fatalf("constraints() error = %v", err)
+	}
+
+	versions := []string{"v1.8.0", "v1.9.0", "v1.9.5", "v2.0.0", "not-a-version"}
+	want := []string{"v1.9.0", "v1.9.5"}
+
+	got := filterByConstraints(versions, constraints)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("filterByConstraints() = %v, want %v", got, want)
+	}
+}
+
+func TestFilterByConstraintsNilPassesThrough(t *testing.T) {
+	versions := []string{"v1.8.0", "v2.0.0"}
+	got := filterByConstraints(versions, nil)
+	if !reflect.DeepEqual(got, versions) {
+		t.Errorf("filterByConstraints(nil) = %v, want %v unchanged", got, versions)
+	}
+}
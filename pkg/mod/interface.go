@@ -2,7 +2,11 @@ package mod
 
 type Interface interface {
 	GoVersion() (string, error)
-	GoGet(module string) error
-	// 根据本地或 go.mod 中的 go 版本，获取兼容的版本
+	// BatchGet resolves and installs multiple modules concurrently, returning
+	// each module's outcome keyed by the exact string passed in modules.
+	BatchGet(modules []string, refresh bool, major int) map[string]error
+	// GoModTidy parses go.mod in the current directory, computes the
+	// module's actual import set via go/packages, runs Minimum Version
+	// Selection over it, and rewrites go.mod and go.sum to match.
 	GoModTidy() error
 }
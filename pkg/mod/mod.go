@@ -8,7 +8,12 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"sync"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+	"golang.org/x/sync/singleflight"
 )
 
 var (
@@ -24,15 +29,39 @@ type versionResult struct {
 	compatible bool
 }
 
+// sumCacheEntry holds the verified h1: hashes for a single module@version,
+// cached so repeat installs skip the sumdb round-trip.
+type sumCacheEntry struct {
+	ModHash string `json:"modHash"`
+	ZipHash string `json:"zipHash"`
+}
+
+// cacheFile is the on-disk shape of ~/.mod_cache.json: the existing
+// per-Go-version compatible module map, plus sibling "sums" and
+// "buildLists" maps of verified checksums and resolved MVS build lists.
+type cacheFile struct {
+	Versions   map[string]map[string]string `json:"versions"`
+	Sums       map[string]sumCacheEntry     `json:"sums,omitempty"`
+	BuildLists map[string][]module.Version  `json:"buildLists,omitempty"`
+}
+
 type Manager struct {
-	modMap    map[string]map[string]string
-	cachePath string
-	verbose   bool
-	mutex     sync.Mutex
-	sem       chan struct{}
+	modMap     map[string]map[string]string
+	sumCache   map[string]sumCacheEntry
+	buildLists map[string][]module.Version
+	cachePath  string
+	verbose    bool
+	proxy      string
+	insecure   bool
+	mutex      sync.Mutex
+	sem        chan struct{}
+	sf         singleflight.Group
 }
 
-func NewManager(verbose bool) *Manager {
+// NewManager creates a Manager. proxy overrides the GOPROXY environment
+// variable when non-empty; pass "" to honor GOPROXY (or the tool's default
+// mirror) instead. insecure skips GOSUMDB checksum verification entirely.
+func NewManager(verbose bool, proxy string, insecure bool) *Manager {
 	homeDir, err := os.UserHomeDir()
 	cachePath := filepath.Join(homeDir, ".mod_cache.json")
 	if err != nil {
@@ -40,10 +69,14 @@ func NewManager(verbose bool) *Manager {
 	}
 
 	return &Manager{
-		modMap:    make(map[string]map[string]string),
-		cachePath: cachePath,
-		verbose:   verbose,
-		sem:       make(chan struct{}, MaxConcurrent),
+		modMap:     make(map[string]map[string]string),
+		sumCache:   make(map[string]sumCacheEntry),
+		buildLists: make(map[string][]module.Version),
+		cachePath:  cachePath,
+		verbose:    verbose,
+		proxy:      proxy,
+		insecure:   insecure,
+		sem:        make(chan struct{}, MaxConcurrent),
 	}
 }
 
@@ -83,51 +116,8 @@ func (m *Manager) GoVersion() (string, error) {
 	return "", fmt.Errorf("unable to parse Go version: %s", string(output))
 }
 
-func (m *Manager) GoGet(module string, refresh bool) error {
-	localGoVersion, err := m.GoVersion()
-	if err != nil {
-		return fmt.Errorf("failed to get local Go version: %w", err)
-	}
-
-	if err = m.loadCache(); err != nil {
-		return err
-	}
-
-	versions, err := listVersion(module, m.verbose)
-	if err != nil || len(versions) == 0 {
-		return fmt.Errorf("failed to get available versions for %s: %w", module, err)
-	}
-	m.logInfo("Module %s has %d available versions", module, len(versions))
-
-	var compatibleVersion string
-	var findErr error
-
-	if refresh {
-		m.logInfo("Force refreshing cache for %s", module)
-		compatibleVersion, findErr = m.findCompatibleVersionRemote(module, versions, localGoVersion)
-	} else {
-		compatibleVersion, findErr = m.findCompatibleVersion(module, versions, localGoVersion)
-	}
-
-	if findErr != nil {
-		return findErr
-	}
-
-	m.logInfo("Executing: go get %s@%s", module, compatibleVersion)
-	cmd := exec.Command("go", "get", fmt.Sprintf("%s@%s", module, compatibleVersion))
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to execute go get command: %w", err)
-	}
-
-	m.logInfo("Successfully installed %s@%s", module, compatibleVersion)
-	return nil
-}
-
-func (m *Manager) findCompatibleVersion(module string, versions []string, localGoVersion string) (string, error) {
-	if compatibleVersion := m.findCompatibleVersionFromCache(module, versions, localGoVersion); compatibleVersion != "" {
+func (m *Manager) findCompatibleVersion(module, cacheKey string, versions []string, localGoVersion string) (string, error) {
+	if compatibleVersion := m.findCompatibleVersionFromCache(cacheKey, versions, localGoVersion); compatibleVersion != "" {
 		return compatibleVersion, nil
 	}
 
@@ -151,7 +141,7 @@ func (m *Manager) findCompatibleVersion(module string, versions []string, localG
 			m.sem <- struct{}{}
 			defer func() { <-m.sem }()
 
-			goVer, err := getModuleGoVersion(module, ver, m.verbose)
+			goVer, err := m.getModuleGoVersionOnce(module, ver)
 			if err != nil {
 				m.logDebug("Failed to get Go requirement for version %s: %v", ver, err)
 				resultChan <- versionResult{version: ver, compatible: false}
@@ -181,24 +171,36 @@ func (m *Manager) findCompatibleVersion(module string, versions []string, localG
 	var selectedVersion string
 	for i := len(versions) - 1; i >= 0; i-- {
 		v := versions[i]
-		if compatibleVersions[v] {
-			selectedVersion = v
-			m.logDebug("Selected latest compatible version: %s", v)
+		if !compatibleVersions[v] {
+			continue
+		}
 
-			m.modMap[localGoVersion][module] = selectedVersion
+		if err := m.verifyChecksum(module, v); err != nil {
+			m.logDebug("Rejecting %s@%s: %v", module, v, err)
+			continue
+		}
 
-			if err := m.saveCache(); err != nil {
-				m.logDebug("Failed to save cache: %v", err)
-			}
+		if err := m.checkBuildList(module, v, localGoVersion); err != nil {
+			m.logDebug("Rejecting %s@%s: %v", module, v, err)
+			continue
+		}
+
+		selectedVersion = v
+		m.logDebug("Selected latest compatible version: %s", v)
 
-			return selectedVersion, nil
+		m.modMap[localGoVersion][cacheKey] = selectedVersion
+
+		if err := m.saveCache(); err != nil {
+			m.logDebug("Failed to save cache: %v", err)
 		}
+
+		return selectedVersion, nil
 	}
 
 	return "", fmt.Errorf("no compatible version found for %s with Go %s", module, localGoVersion)
 }
 
-func (m *Manager) findCompatibleVersionFromCache(module string, versions []string, localGoVersion string) string {
+func (m *Manager) findCompatibleVersionFromCache(cacheKey string, versions []string, localGoVersion string) string {
 	m.mutex.Lock()
 	goVersionMap, exists := m.modMap[localGoVersion]
 	m.mutex.Unlock()
@@ -210,7 +212,7 @@ func (m *Manager) findCompatibleVersionFromCache(module string, versions []strin
 	m.logDebug("Getting module info from cache")
 
 	m.mutex.Lock()
-	cachedVersion, exists := goVersionMap[module]
+	cachedVersion, exists := goVersionMap[cacheKey]
 	m.mutex.Unlock()
 
 	if !exists {
@@ -227,7 +229,7 @@ func (m *Manager) findCompatibleVersionFromCache(module string, versions []strin
 	return ""
 }
 
-func (m *Manager) findCompatibleVersionRemote(module string, versions []string, localGoVersion string) (string, error) {
+func (m *Manager) findCompatibleVersionRemote(module, cacheKey string, versions []string, localGoVersion string) (string, error) {
 	m.logDebug("Skipping cache, checking remotely for latest versions")
 
 	m.mutex.Lock()
@@ -248,7 +250,7 @@ func (m *Manager) findCompatibleVersionRemote(module string, versions []string,
 			m.sem <- struct{}{}
 			defer func() { <-m.sem }()
 
-			goVer, err := getModuleGoVersion(module, ver, m.verbose)
+			goVer, err := m.getModuleGoVersionOnce(module, ver)
 			if err != nil {
 				m.logDebug("Failed to get Go requirement for version %s: %v", ver, err)
 				resultChan <- versionResult{version: ver, compatible: false}
@@ -278,53 +280,214 @@ func (m *Manager) findCompatibleVersionRemote(module string, versions []string,
 	var selectedVersion string
 	for i := len(versions) - 1; i >= 0; i-- {
 		v := versions[i]
-		if compatibleVersions[v] {
-			selectedVersion = v
-			m.logDebug("Selected latest compatible version: %s", v)
+		if !compatibleVersions[v] {
+			continue
+		}
 
-			m.modMap[localGoVersion][module] = selectedVersion
+		if err := m.verifyChecksum(module, v); err != nil {
+			m.logDebug("Rejecting %s@%s: %v", module, v, err)
+			continue
+		}
 
-			if err := m.saveCache(); err != nil {
-				m.logDebug("Failed to save cache: %v", err)
-			}
+		if err := m.checkBuildList(module, v, localGoVersion); err != nil {
+			m.logDebug("Rejecting %s@%s: %v", module, v, err)
+			continue
+		}
 
-			return selectedVersion, nil
+		selectedVersion = v
+		m.logDebug("Selected latest compatible version: %s", v)
+
+		m.modMap[localGoVersion][cacheKey] = selectedVersion
+
+		if err := m.saveCache(); err != nil {
+			m.logDebug("Failed to save cache: %v", err)
 		}
+
+		return selectedVersion, nil
 	}
 
 	return "", fmt.Errorf("no compatible version found for %s with Go %s", module, localGoVersion)
 }
 
-func (m *Manager) GoModTidy() error {
-	return nil
+// BatchGet resolves the compatible version of every module in modules
+// concurrently, sharing this Manager's sem budget across all of them, and
+// then installs the whole set with a single 'go get' invocation so the
+// module graph is solved once. Each entry in modules may carry a
+// "@constraint" suffix, parsed by parseModuleSpec; major, if 2
+// or above, rewrites every module's path with a /vN suffix before
+// resolution, and any major of 1 or above restricts selection to versions
+// of that major. It returns each module's outcome keyed by the exact string
+// passed in modules; a nil entry means the module was installed
+// successfully.
+func (m *Manager) BatchGet(modules []string, refresh bool, major int) map[string]error {
+	results := make(map[string]error, len(modules))
+	var mu sync.Mutex
+
+	localGoVersion, err := m.GoVersion()
+	if err != nil {
+		wrapped := fmt.Errorf("failed to get local Go version: %w", err)
+		for _, module := range modules {
+			results[module] = wrapped
+		}
+		return results
+	}
+
+	if err := m.loadCache(); err != nil {
+		for _, module := range modules {
+			results[module] = err
+		}
+		return results
+	}
+
+	type resolved struct {
+		raw     string
+		module  string
+		version string
+	}
+
+	resolvedChan := make(chan resolved, len(modules))
+	var wg sync.WaitGroup
+
+	for _, raw := range modules {
+		wg.Add(1)
+		go func(raw string) {
+			defer wg.Done()
+
+			spec := parseModuleSpec(raw, major)
+
+			versions, err := m.listVersion(spec.Path)
+			if err != nil || len(versions) == 0 {
+				mu.Lock()
+				results[raw] = fmt.Errorf("failed to get available versions for %s: %w", spec.Path, err)
+				mu.Unlock()
+				return
+			}
+
+			constraints, err := spec.constraints()
+			if err != nil {
+				mu.Lock()
+				results[raw] = err
+				mu.Unlock()
+				return
+			}
+			versions = filterByConstraints(versions, constraints)
+			if major >= 1 {
+				wantMajor := fmt.Sprintf("v%d", major)
+				var sameMajor []string
+				for _, v := range versions {
+					if semver.Major(v) == wantMajor {
+						sameMajor = append(sameMajor, v)
+					}
+				}
+				versions = sameMajor
+			}
+			if len(versions) == 0 {
+				mu.Lock()
+				results[raw] = fmt.Errorf("no version of %s satisfies constraint %q", spec.Path, spec.Constraint)
+				mu.Unlock()
+				return
+			}
+			m.logInfo("Module %s has %d available versions matching constraint", spec.Path, len(versions))
+
+			var compatibleVersion string
+			var findErr error
+
+			if refresh {
+				compatibleVersion, findErr = m.findCompatibleVersionRemote(spec.Path, spec.cacheKey(), versions, localGoVersion)
+			} else {
+				compatibleVersion, findErr = m.findCompatibleVersion(spec.Path, spec.cacheKey(), versions, localGoVersion)
+			}
+
+			if findErr != nil {
+				mu.Lock()
+				results[raw] = findErr
+				mu.Unlock()
+				return
+			}
+
+			resolvedChan <- resolved{raw: raw, module: spec.Path, version: compatibleVersion}
+		}(raw)
+	}
+
+	wg.Wait()
+	close(resolvedChan)
+
+	var getArgs []string
+	var resolvedList []resolved
+	for r := range resolvedChan {
+		getArgs = append(getArgs, fmt.Sprintf("%s@%s", r.module, r.version))
+		resolvedList = append(resolvedList, r)
+	}
+
+	if len(getArgs) == 0 {
+		return results
+	}
+
+	m.logInfo("Executing: go get %s", strings.Join(getArgs, " "))
+	cmd := exec.Command("go", append([]string{"get"}, getArgs...)...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		wrapped := fmt.Errorf("failed to execute go get command: %w", err)
+		for _, r := range resolvedList {
+			mu.Lock()
+			results[r.raw] = wrapped
+			mu.Unlock()
+		}
+		return results
+	}
+
+	for _, r := range resolvedList {
+		m.logInfo("Successfully installed %s@%s", r.module, r.version)
+		mu.Lock()
+		results[r.raw] = nil
+		mu.Unlock()
+	}
+
+	return results
 }
 
 func (m *Manager) loadCache() error {
 	m.logDebug("Loading cache: %s", m.cachePath)
 	m.modMap = make(map[string]map[string]string)
+	m.sumCache = make(map[string]sumCacheEntry)
+	m.buildLists = make(map[string][]module.Version)
 
 	data, err := os.ReadFile(m.cachePath)
 	if err != nil {
 		m.logDebug("Failed to read cache file: %v, creating new cache", err)
-		emptyCache, _ := json.MarshalIndent(m.modMap, "", "  ")
-
-		return os.WriteFile(m.cachePath, emptyCache, 0644)
+		return m.writeCacheFile()
 	}
 
-	if err = json.Unmarshal(data, &m.modMap); err != nil {
+	var cache cacheFile
+	if err = json.Unmarshal(data, &cache); err != nil {
 		m.logDebug("Failed to parse cache: %v, resetting cache", err)
-		m.modMap = make(map[string]map[string]string)
-		emptyCache, _ := json.MarshalIndent(m.modMap, "", "  ")
-		return os.WriteFile(m.cachePath, emptyCache, 0644)
-	} else {
-		m.logDebug("Successfully loaded cache with %d modules", len(m.modMap))
+		return m.writeCacheFile()
+	}
+
+	if cache.Versions != nil {
+		m.modMap = cache.Versions
+	}
+	if cache.Sums != nil {
+		m.sumCache = cache.Sums
+	}
+	if cache.BuildLists != nil {
+		m.buildLists = cache.BuildLists
 	}
+
+	m.logDebug("Successfully loaded cache with %d modules, %d verified sums, %d build lists", len(m.modMap), len(m.sumCache), len(m.buildLists))
 	return nil
 }
 
+func (m *Manager) writeCacheFile() error {
+	data, _ := json.MarshalIndent(cacheFile{Versions: m.modMap, Sums: m.sumCache, BuildLists: m.buildLists}, "", "  ")
+	return os.WriteFile(m.cachePath, data, 0644)
+}
+
 func (m *Manager) saveCache() error {
 	m.logDebug("Saving cache: %s", m.cachePath)
-	data, err := json.MarshalIndent(m.modMap, "", "  ")
+	data, err := json.MarshalIndent(cacheFile{Versions: m.modMap, Sums: m.sumCache, BuildLists: m.buildLists}, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to serialize cache: %w", err)
 	}
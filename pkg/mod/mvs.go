@@ -0,0 +1,211 @@
+package mod
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// Reqs describes how to walk a module's dependency graph for Minimum
+// Version Selection: Required lists the modules a given module version
+// requires, and Upgrade resolves a module path to the version MVS should
+// treat as available when nothing else in the graph constrains it.
+type Reqs interface {
+	Required(mod module.Version) ([]module.Version, error)
+	Upgrade(mod module.Version) (module.Version, error)
+}
+
+var _ Reqs = (*Manager)(nil)
+
+// Required implements Reqs by parsing the require directives out of
+// mod's go.mod, fetched through the same GOPROXY chain as listVersion and
+// getModuleGoVersion.
+func (m *Manager) Required(mod module.Version) ([]module.Version, error) {
+	data, err := m.fetchRawGoMod(mod.Path, mod.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := modfile.Parse(mod.Path+"@"+mod.Version+"/go.mod", data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.mod for %s@%s: %w", mod.Path, mod.Version, err)
+	}
+
+	required := make([]module.Version, 0, len(f.Require))
+	for _, r := range f.Require {
+		required = append(required, r.Mod)
+	}
+
+	return required, nil
+}
+
+// Upgrade implements Reqs: the version MVS should treat as available for
+// mod.Path when nothing else in the graph constrains it is the latest
+// version listVersion reports.
+func (m *Manager) Upgrade(mod module.Version) (module.Version, error) {
+	versions, err := m.listVersion(mod.Path)
+	if err != nil || len(versions) == 0 {
+		return module.Version{}, fmt.Errorf("no versions available to upgrade %s: %w", mod.Path, err)
+	}
+
+	latest := versions[0]
+	for _, v := range versions[1:] {
+		if semver.Compare(v, latest) > 0 {
+			latest = v
+		}
+	}
+
+	return module.Version{Path: mod.Path, Version: latest}, nil
+}
+
+// goDirective parses data as a go.mod file and returns its go directive
+// ("" if absent).
+func goDirective(data []byte) (string, error) {
+	f, err := modfile.Parse("go.mod", data, nil)
+	if err != nil {
+		return "", err
+	}
+	if f.Go == nil {
+		return "", nil
+	}
+	return f.Go.Version, nil
+}
+
+// resolveBuildList computes the full Minimum Version Selection build list
+// for module@version under localGoVersion, caching the result (in memory
+// and in ~/.mod_cache.json) keyed by (localGoVersion, module, version) so
+// repeat resolutions of the same candidate are O(1).
+func (m *Manager) resolveBuildList(mod, version, localGoVersion string) ([]module.Version, error) {
+	key := localGoVersion + "|" + mod + "@" + version
+
+	m.mutex.Lock()
+	if cached, ok := m.buildLists[key]; ok {
+		m.mutex.Unlock()
+		return cached, nil
+	}
+	m.mutex.Unlock()
+
+	list, err := m.buildList(module.Version{Path: mod, Version: version})
+	if err != nil {
+		return nil, err
+	}
+
+	m.mutex.Lock()
+	if m.buildLists == nil {
+		m.buildLists = make(map[string][]module.Version)
+	}
+	m.buildLists[key] = list
+	m.mutex.Unlock()
+
+	if err := m.saveCache(); err != nil {
+		m.logDebug("Failed to save build list cache: %v", err)
+	}
+
+	return list, nil
+}
+
+// buildList runs a single-root Minimum Version Selection pass; see
+// buildListMulti.
+func (m *Manager) buildList(root module.Version) ([]module.Version, error) {
+	return m.buildListMulti([]module.Version{root})
+}
+
+// buildListMulti runs a breadth-first Minimum Version Selection pass
+// starting from roots: for each module path reachable through "require"
+// directives, it keeps the maximum version requested anywhere in the
+// graph. Each frontier's Required calls run concurrently, bounded by
+// m.sem, as vgo's parallel-MVS work did.
+func (m *Manager) buildListMulti(roots []module.Version) ([]module.Version, error) {
+	type requireResult struct {
+		mod      module.Version
+		required []module.Version
+		err      error
+	}
+
+	selected := make(map[string]string, len(roots))
+	visited := make(map[module.Version]bool, len(roots))
+	var frontier []module.Version
+	for _, root := range roots {
+		if cur, ok := selected[root.Path]; !ok || semver.Compare(root.Version, cur) > 0 {
+			selected[root.Path] = root.Version
+		}
+		if !visited[root] {
+			visited[root] = true
+			frontier = append(frontier, root)
+		}
+	}
+
+	for len(frontier) > 0 {
+		resultChan := make(chan requireResult, len(frontier))
+		var wg sync.WaitGroup
+
+		for _, mod := range frontier {
+			wg.Add(1)
+			go func(mod module.Version) {
+				defer wg.Done()
+
+				m.sem <- struct{}{}
+				defer func() { <-m.sem }()
+
+				required, err := m.Required(mod)
+				resultChan <- requireResult{mod: mod, required: required, err: err}
+			}(mod)
+		}
+
+		wg.Wait()
+		close(resultChan)
+
+		var next []module.Version
+		for res := range resultChan {
+			if res.err != nil {
+				return nil, fmt.Errorf("failed to read requirements of %s@%s: %w", res.mod.Path, res.mod.Version, res.err)
+			}
+
+			for _, r := range res.required {
+				if cur, ok := selected[r.Path]; !ok || semver.Compare(r.Version, cur) > 0 {
+					selected[r.Path] = r.Version
+				}
+				if !visited[r] {
+					visited[r] = true
+					next = append(next, r)
+				}
+			}
+		}
+
+		frontier = next
+	}
+
+	list := make([]module.Version, 0, len(selected))
+	for path, ver := range selected {
+		list = append(list, module.Version{Path: path, Version: ver})
+	}
+
+	return list, nil
+}
+
+// checkBuildList rejects module@version if Minimum Version Selection finds
+// any module in its dependency graph whose go directive exceeds
+// localGoVersion, i.e. a version that would actually fail to build.
+func (m *Manager) checkBuildList(mod, version, localGoVersion string) error {
+	list, err := m.resolveBuildList(mod, version, localGoVersion)
+	if err != nil {
+		return err
+	}
+
+	for _, dep := range list {
+		goVer, err := m.getModuleGoVersionOnce(dep.Path, dep.Version)
+		if err != nil {
+			m.logDebug("Failed to get Go requirement for %s@%s while checking build list: %v", dep.Path, dep.Version, err)
+			continue
+		}
+
+		if goVer != "" && !compareGoVersions(localGoVersion, "go"+goVer) {
+			return fmt.Errorf("%s@%s requires %s@%s, which needs go%s (local is %s)", mod, version, dep.Path, dep.Version, goVer, localGoVersion)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package mod
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+)
+
+// Pseudo-entries recognized in a GOPROXY-style list, mirroring the go
+// command: "direct" fetches straight from the module's VCS, "off" disables
+// fetches entirely.
+const (
+	proxyDirect = "direct"
+	proxyOff    = "off"
+)
+
+// defaultGoproxy is used when GOPROXY is unset or empty: try the goproxy.cn
+// mirror first, then fall back to a direct fetch.
+var defaultGoproxy = GoproxyCN + ",direct"
+
+// proxyEntry is one segment of a GOPROXY-style value, along with the
+// separator that followed it. A "," separator only falls through to the
+// next entry on a module-not-found (404/410) response; a "|" separator
+// falls through on any error, matching the go command's semantics.
+type proxyEntry struct {
+	value          string
+	fallThroughAny bool
+}
+
+// errModuleNotFound signals a 404/410 from a proxy, the only proxy error
+// that continues a "," separated GOPROXY chain to the next entry.
+type errModuleNotFound struct {
+	module  string
+	version string
+}
+
+func (e *errModuleNotFound) Error() string {
+	if e.version != "" {
+		return fmt.Sprintf("module %s@%s not found", e.module, e.version)
+	}
+	return fmt.Sprintf("module %s not found", e.module)
+}
+
+// parseProxyList splits a GOPROXY-style value into an ordered chain of
+// candidate proxies, recording whether each was followed by "|" rather
+// than ",".
+func parseProxyList(raw string) []proxyEntry {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		raw = defaultGoproxy
+	}
+
+	var entries []proxyEntry
+	var cur strings.Builder
+
+	flush := func(fallThroughAny bool) {
+		v := strings.TrimSpace(cur.String())
+		if v != "" {
+			entries = append(entries, proxyEntry{value: v, fallThroughAny: fallThroughAny})
+		}
+		cur.Reset()
+	}
+
+	for _, r := range raw {
+		switch r {
+		case ',':
+			flush(false)
+		case '|':
+			flush(true)
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	flush(false)
+
+	return entries
+}
+
+// proxyChain resolves the GOPROXY chain to use for this Manager: the
+// --proxy flag takes precedence over the GOPROXY environment variable.
+func (m *Manager) proxyChain() []proxyEntry {
+	val := m.proxy
+	if val == "" {
+		val = os.Getenv("GOPROXY")
+	}
+	return parseProxyList(val)
+}
+
+// isPrivateModule reports whether module should bypass the public proxy
+// chain entirely, per GONOPROXY/GOPRIVATE.
+func isPrivateModule(module string) bool {
+	if v := os.Getenv("GONOPROXY"); v != "" && matchesGlobList(module, v) {
+		return true
+	}
+	if v := os.Getenv("GOPRIVATE"); v != "" && matchesGlobList(module, v) {
+		return true
+	}
+	return false
+}
+
+// matchesGlobList reports whether module matches any comma-separated glob
+// pattern in patterns.
+func matchesGlobList(module, patterns string) bool {
+	for _, pat := range strings.Split(patterns, ",") {
+		pat = strings.TrimSpace(pat)
+		if pat == "" {
+			continue
+		}
+		if matchesGlobPrefix(module, pat) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesGlobPrefix reports whether pattern matches module path-element by
+// path-element, allowing pattern to name a prefix of module.
+func matchesGlobPrefix(module, pattern string) bool {
+	modParts := strings.Split(module, "/")
+	patParts := strings.Split(pattern, "/")
+	if len(patParts) > len(modParts) {
+		return false
+	}
+
+	for i, p := range patParts {
+		ok, err := path.Match(p, modParts[i])
+		if err != nil || !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// directListVersions shells out to `go list -m -versions` with GOPROXY set
+// to entry ("direct" or "off"), so the go command performs (or refuses)
+// the fetch itself rather than us talking to a proxy.
+func directListVersions(module, entry string, verbose bool) ([]string, error) {
+	cmd := exec.Command("go", "list", "-m", "-versions", "-json", module)
+	cmd.Env = append(os.Environ(), "GOPROXY="+entry)
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("go list -m -versions %s (GOPROXY=%s): %w: %s", module, entry, err, strings.TrimSpace(out.String()))
+	}
+
+	var result struct {
+		Versions []string `json:"Versions"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse go list output for %s: %w", module, err)
+	}
+
+	if verbose {
+		log.Printf("Module %s has %d versions via GOPROXY=%s", module, len(result.Versions), entry)
+	}
+
+	return result.Versions, nil
+}
+
+// directModuleGoVersion shells out to `go mod download -json` with GOPROXY
+// set to entry, then parses the go directive out of the downloaded go.mod.
+func directModuleGoVersion(module, version, entry string) (string, error) {
+	data, err := directDownload(module, version, entry, "GoMod")
+	if err != nil {
+		return "", err
+	}
+
+	return goDirective(data)
+}
+
+// directGoModBytes shells out to `go mod download -json` with GOPROXY set
+// to entry and returns the raw go.mod bytes, used to verify the module's
+// checksum.
+func directGoModBytes(module, version, entry string) ([]byte, error) {
+	return directDownload(module, version, entry, "GoMod")
+}
+
+// directZipBytes shells out to `go mod download -json` with GOPROXY set to
+// entry and returns the raw module zip bytes, used to verify the module's
+// checksum.
+func directZipBytes(module, version, entry string) ([]byte, error) {
+	return directDownload(module, version, entry, "Zip")
+}
+
+// directDownload shells out to `go mod download -json` with GOPROXY set to
+// entry and returns the file named by the given field of its JSON output
+// ("GoMod" or "Zip").
+func directDownload(module, version, entry, field string) ([]byte, error) {
+	cmd := exec.Command("go", "mod", "download", "-json", fmt.Sprintf("%s@%s", module, version))
+	cmd.Env = append(os.Environ(), "GOPROXY="+entry)
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("go mod download %s@%s (GOPROXY=%s): %w: %s", module, version, entry, err, strings.TrimSpace(out.String()))
+	}
+
+	var result struct {
+		GoMod string `json:"GoMod"`
+		Zip   string `json:"Zip"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse go mod download output for %s@%s: %w", module, version, err)
+	}
+
+	path := result.GoMod
+	if field == "Zip" {
+		path = result.Zip
+	}
+	if path == "" {
+		return nil, fmt.Errorf("go mod download output for %s@%s had no %s path", module, version, field)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s for %s@%s: %w", field, module, version, err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,133 @@
+package mod
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseProxyList(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []proxyEntry
+	}{
+		{
+			name: "empty falls back to default",
+			raw:  "",
+			want: parseProxyList(defaultGoproxy),
+		},
+		{
+			name: "comma separated",
+			raw:  "https://a.example/,https://b.example/,direct",
+			want: []proxyEntry{
+				{value: "https://a.example/", fallThroughAny: false},
+				{value: "https://b.example/", fallThroughAny: false},
+				{value: "direct", fallThroughAny: false},
+			},
+		},
+		{
+			name: "pipe separated",
+			raw:  "https://a.example/|https://b.example/",
+			want: []proxyEntry{
+				{value: "https://a.example/", fallThroughAny: true},
+				{value: "https://b.example/", fallThroughAny: false},
+			},
+		},
+		{
+			name: "mixed separators",
+			raw:  "https://a.example/|https://b.example/,direct",
+			want: []proxyEntry{
+				{value: "https://a.example/", fallThroughAny: true},
+				{value: "https://b.example/", fallThroughAny: false},
+				{value: "direct", fallThroughAny: false},
+			},
+		},
+		{
+			name: "blank entries are dropped",
+			raw:  "https://a.example/,,https://b.example/",
+			want: []proxyEntry{
+				{value: "https://a.example/", fallThroughAny: false},
+				{value: "https://b.example/", fallThroughAny: false},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseProxyList(tt.raw)
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseProxyList(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("parseProxyList(%q)[%d] = %+v, want %+v", tt.raw, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// versionListServer starts an httptest.Server whose @v/list response is
+// governed by status: http.StatusOK serves versions, anything else returns
+// that status with an empty body.
+func versionListServer(t *testing.T, status int, versions string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(versions))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestListVersionCommaFallsThroughOnlyOnNotFound(t *testing.T) {
+	notFound := versionListServer(t, http.StatusNotFound, "")
+	ok := versionListServer(t, http.StatusOK, "v1.0.0\nv1.1.0\n")
+
+	m := NewManager(false, notFound.URL+"/,"+ok.URL+"/", false)
+	versions, err := m.listVersion("example.com/mod")
+	if err != nil {
+		t.Fatalf("listVersion() error = %v", err)
+	}
+	if len(versions) != 2 || versions[0] != "v1.0.0" || versions[1] != "v1.1.0" {
+		t.Errorf("listVersion() = %v, want [v1.0.0 v1.1.0]", versions)
+	}
+}
+
+func TestListVersionCommaStopsOnOtherError(t *testing.T) {
+	serverErr := versionListServer(t, http.StatusInternalServerError, "")
+	ok := versionListServer(t, http.StatusOK, "v1.0.0\n")
+
+	m := NewManager(false, serverErr.URL+"/,"+ok.URL+"/", false)
+	if _, err := m.listVersion("example.com/mod"); err == nil {
+		t.Fatal("listVersion() error = nil, want non-nil: a \",\" chain must not fall through on a non-404/410 error")
+	}
+}
+
+func TestListVersionPipeFallsThroughOnAnyError(t *testing.T) {
+	serverErr := versionListServer(t, http.StatusInternalServerError, "")
+	ok := versionListServer(t, http.StatusOK, "v1.0.0\n")
+
+	m := NewManager(false, serverErr.URL+"/|"+ok.URL+"/", false)
+	versions, err := m.listVersion("example.com/mod")
+	if err != nil {
+		t.Fatalf("listVersion() error = %v", err)
+	}
+	if len(versions) != 1 || versions[0] != "v1.0.0" {
+		t.Errorf("listVersion() = %v, want [v1.0.0]", versions)
+	}
+}
+
+func TestListVersionAllProxiesFail(t *testing.T) {
+	a := versionListServer(t, http.StatusNotFound, "")
+	b := versionListServer(t, http.StatusNotFound, "")
+
+	m := NewManager(false, a.URL+"/,"+b.URL+"/", false)
+	if _, err := m.listVersion("example.com/mod"); err == nil {
+		t.Fatal("listVersion() error = nil, want non-nil when every proxy in the chain returns not-found")
+	}
+}
@@ -0,0 +1,334 @@
+package mod
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/tools/go/packages"
+)
+
+// TidyResult is what analyzing a module for `go-get tidy` produces: the
+// go.mod/go.sum content it proposes, alongside what's on disk today, so a
+// caller can either write it out (GoModTidy/ApplyTidy) or render it as a
+// diff (--dry-run).
+type TidyResult struct {
+	GoModPath string
+	GoSumPath string
+	OldGoMod  []byte
+	NewGoMod  []byte
+	OldGoSum  []byte
+	NewGoSum  []byte
+	// Added lists the modules AnalyzeTidy added to the require list;
+	// Removed lists the direct requires no package imports any more, which
+	// may stay in the list as indirect. Both are for a short human-readable
+	// summary.
+	Added   []module.Version
+	Removed []module.Version
+}
+
+// GoModTidy implements Interface.GoModTidy: it analyzes go.mod/go.sum in
+// the current directory against the packages the module's Go source
+// actually imports, and rewrites both files to match.
+func (m *Manager) GoModTidy() error {
+	result, err := m.AnalyzeTidy(".")
+	if err != nil {
+		return err
+	}
+	return m.ApplyTidy(result)
+}
+
+// AnalyzeTidy computes the tidy result for the module rooted at dir
+// without writing anything to disk: it walks dir's Go source with
+// go/packages to find the modules it actually imports, resolves any that
+// aren't already required via findCompatibleVersion, then runs Minimum
+// Version Selection over the combined set to get the full require list
+// (direct and indirect), and rewrites go.mod/go.sum in memory to match.
+func (m *Manager) AnalyzeTidy(dir string) (*TidyResult, error) {
+	goModPath := filepath.Join(dir, "go.mod")
+	oldGoMod, err := os.ReadFile(goModPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.mod: %w", err)
+	}
+
+	f, err := modfile.Parse(goModPath, oldGoMod, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	if f.Module == nil {
+		return nil, fmt.Errorf("go.mod has no module directive")
+	}
+
+	localGoVersion, err := m.GoVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get local Go version: %w", err)
+	}
+
+	imported, err := importedModules(dir, f.Module.Mod.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	// existingAll covers every require in go.mod, direct or indirect: a
+	// module already pinned as indirect that's now imported directly
+	// should just flip to direct at its existing version, not get
+	// re-resolved to the latest compatible one. existingDirect is only
+	// the direct requires, used below to report what tidy drops.
+	existingAll := make(map[string]string, len(f.Require))
+	existingDirect := make(map[string]string, len(f.Require))
+	for _, r := range f.Require {
+		existingAll[r.Mod.Path] = r.Mod.Version
+		if !r.Indirect {
+			existingDirect[r.Mod.Path] = r.Mod.Version
+		}
+	}
+
+	var missing []string
+	for path := range imported {
+		if _, ok := existingAll[path]; !ok {
+			missing = append(missing, path)
+		}
+	}
+	sort.Strings(missing)
+
+	resolved, err := m.resolveMissingRequires(missing, localGoVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := make([]module.Version, 0, len(existingAll)+len(resolved))
+	for path, ver := range existingAll {
+		if imported[path] {
+			roots = append(roots, module.Version{Path: path, Version: ver})
+		}
+	}
+	for path, ver := range resolved {
+		roots = append(roots, module.Version{Path: path, Version: ver})
+	}
+
+	fullList, err := m.buildListMulti(roots)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(fullList, func(i, j int) bool { return fullList[i].Path < fullList[j].Path })
+
+	newReqs := make([]*modfile.Require, 0, len(fullList))
+	var added, removed []module.Version
+	for _, v := range fullList {
+		newReqs = append(newReqs, &modfile.Require{Mod: v, Indirect: !imported[v.Path]})
+		if _, ok := existingAll[v.Path]; !ok {
+			added = append(added, v)
+		}
+	}
+	for path, ver := range existingDirect {
+		if !imported[path] {
+			removed = append(removed, module.Version{Path: path, Version: ver})
+		}
+	}
+
+	f.SetRequire(newReqs)
+
+	stillRequired := make(map[string]bool, len(newReqs))
+	for _, r := range newReqs {
+		stillRequired[r.Mod.Path] = true
+	}
+	for _, rep := range f.Replace {
+		if !stillRequired[rep.Old.Path] {
+			if err := f.DropReplace(rep.Old.Path, rep.Old.Version); err != nil {
+				return nil, fmt.Errorf("failed to drop stale replace for %s: %w", rep.Old.Path, err)
+			}
+		}
+	}
+
+	f.Cleanup()
+	newGoMod, err := f.Format()
+	if err != nil {
+		return nil, fmt.Errorf("failed to format go.mod: %w", err)
+	}
+
+	goSumPath := filepath.Join(dir, "go.sum")
+	oldGoSum, _ := os.ReadFile(goSumPath)
+
+	newGoSum := oldGoSum
+	if !m.insecure {
+		newGoSum, err = m.buildGoSum(newReqs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &TidyResult{
+		GoModPath: goModPath,
+		GoSumPath: goSumPath,
+		OldGoMod:  oldGoMod,
+		NewGoMod:  newGoMod,
+		OldGoSum:  oldGoSum,
+		NewGoSum:  newGoSum,
+		Added:     added,
+		Removed:   removed,
+	}, nil
+}
+
+// ApplyTidy atomically writes result's go.mod and go.sum, via a tempfile
+// in the same directory followed by a rename.
+func (m *Manager) ApplyTidy(result *TidyResult) error {
+	if err := writeFileAtomic(result.GoModPath, result.NewGoMod); err != nil {
+		return fmt.Errorf("failed to write go.mod: %w", err)
+	}
+	if err := writeFileAtomic(result.GoSumPath, result.NewGoSum); err != nil {
+		return fmt.Errorf("failed to write go.sum: %w", err)
+	}
+	return nil
+}
+
+// importedModules loads every package under dir and returns the set of
+// distinct module paths its Go source imports, directly or transitively,
+// excluding the module at own (the module being tidied itself).
+func importedModules(dir, own string) (map[string]bool, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Dir:  dir,
+		Mode: packages.NeedName | packages.NeedImports | packages.NeedDeps | packages.NeedModule,
+	}, "./...")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages: %w", err)
+	}
+
+	imported := make(map[string]bool)
+	packages.Visit(pkgs, func(pkg *packages.Package) bool {
+		if pkg.Module != nil && pkg.Module.Path != own {
+			imported[pkg.Module.Path] = true
+		}
+		return true
+	}, nil)
+
+	return imported, nil
+}
+
+// resolveMissingRequires picks a version for each module path in paths
+// that go.mod doesn't already require, by running the same
+// findCompatibleVersion selection BatchGet uses for each module. All
+// paths are resolved concurrently, and their proxy fetches share m.sem's
+// budget, as BatchGet does.
+func (m *Manager) resolveMissingRequires(paths []string, localGoVersion string) (map[string]string, error) {
+	type result struct {
+		path    string
+		version string
+		err     error
+	}
+
+	resultChan := make(chan result, len(paths))
+	var wg sync.WaitGroup
+
+	for _, path := range paths {
+		wg.Add(1)
+		go func(path string) {
+			defer wg.Done()
+
+			versions, err := m.listVersion(path)
+			if err != nil || len(versions) == 0 {
+				resultChan <- result{path: path, err: fmt.Errorf("failed to get available versions for %s: %w", path, err)}
+				return
+			}
+
+			ver, err := m.findCompatibleVersion(path, path, versions, localGoVersion)
+			resultChan <- result{path: path, version: ver, err: err}
+		}(path)
+	}
+
+	wg.Wait()
+	close(resultChan)
+
+	resolved := make(map[string]string, len(paths))
+	for r := range resultChan {
+		if r.err != nil {
+			return nil, r.err
+		}
+		resolved[r.path] = r.version
+	}
+
+	return resolved, nil
+}
+
+// buildGoSum computes the go.sum content for reqs, verifying (and thereby
+// populating m.sumCache for) every entry concurrently, bounded by m.sem.
+func (m *Manager) buildGoSum(reqs []*modfile.Require) ([]byte, error) {
+	type entry struct {
+		path, version, modHash, zipHash string
+		err                             error
+	}
+
+	entryChan := make(chan entry, len(reqs))
+	var wg sync.WaitGroup
+
+	for _, r := range reqs {
+		wg.Add(1)
+		go func(r *modfile.Require) {
+			defer wg.Done()
+
+			m.sem <- struct{}{}
+			defer func() { <-m.sem }()
+
+			if err := m.verifyChecksum(r.Mod.Path, r.Mod.Version); err != nil {
+				entryChan <- entry{err: err}
+				return
+			}
+
+			m.mutex.Lock()
+			cached := m.sumCache[r.Mod.Path+"@"+r.Mod.Version]
+			m.mutex.Unlock()
+
+			entryChan <- entry{path: r.Mod.Path, version: r.Mod.Version, modHash: cached.ModHash, zipHash: cached.ZipHash}
+		}(r)
+	}
+
+	wg.Wait()
+	close(entryChan)
+
+	var lines []string
+	for e := range entryChan {
+		if e.err != nil {
+			return nil, fmt.Errorf("failed to verify checksum while building go.sum: %w", e.err)
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s", e.path, e.version, e.zipHash))
+		lines = append(lines, fmt.Sprintf("%s %s/go.mod %s", e.path, e.version, e.modHash))
+	}
+	sort.Strings(lines)
+
+	if len(lines) == 0 {
+		return nil, nil
+	}
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+// writeFileAtomic writes data to path by creating a tempfile in the same
+// directory and renaming it into place, so readers never see a partially
+// written go.mod/go.sum.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package mod
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -12,13 +13,67 @@ import (
 )
 
 var (
-	ModGoVersionPattern  = regexp.MustCompile(`(?m)^go\s+(\d+\.\d+(\.\d+)?)`)
 	GoproxyCN            = "https://goproxy.cn/"
 	StableVersionPattern = regexp.MustCompile(`^v\d+\.\d+\.\d+$`)
 )
 
-func listVersion(module string, verbose bool) ([]string, error) {
-	url := fmt.Sprintf(GoproxyCN+"%s/@v/list", module)
+// listVersion fetches the available versions for module, trying each proxy
+// in the Manager's GOPROXY chain in turn. A 404/410 (module not found)
+// falls through to the next proxy; any other error terminates the search,
+// unless the failing entry was followed by "|", which falls through on any
+// error.
+func (m *Manager) listVersion(module string) ([]string, error) {
+	if isPrivateModule(module) {
+		m.logDebug("%s matches GONOPROXY/GOPRIVATE, fetching directly", module)
+		rawVersions, err := directListVersions(module, proxyDirect, m.verbose)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list versions for private module %s: %w", module, err)
+		}
+		return filterStableVersions(rawVersions, module, m.verbose), nil
+	}
+
+	chain := m.proxyChain()
+	var lastErr error
+
+	for _, entry := range chain {
+		rawVersions, err := listVersionFrom(module, entry.value, m.verbose)
+		if err == nil {
+			return filterStableVersions(rawVersions, module, m.verbose), nil
+		}
+
+		var notFound *errModuleNotFound
+		if errors.As(err, &notFound) {
+			m.logDebug("Proxy %s has no versions for %s, trying next", entry.value, module)
+			lastErr = err
+			continue
+		}
+
+		if entry.fallThroughAny {
+			m.logDebug("Proxy %s failed for %s, trying next: %v", entry.value, module, err)
+			lastErr = err
+			continue
+		}
+
+		return nil, fmt.Errorf("failed to get available versions for %s from %s: %w", module, entry.value, err)
+	}
+
+	return nil, fmt.Errorf("no proxy in GOPROXY chain resolved %s: %w", module, lastErr)
+}
+
+// listVersionFrom fetches the raw (unfiltered) version list for module from
+// a single GOPROXY chain entry, which may be a proxy URL or the "direct"/
+// "off" pseudo-entries.
+func listVersionFrom(module, entry string, verbose bool) ([]string, error) {
+	switch entry {
+	case proxyDirect, proxyOff:
+		return directListVersions(module, entry, verbose)
+	default:
+		return fetchVersionList(entry, module)
+	}
+}
+
+func fetchVersionList(baseURL, module string) ([]string, error) {
+	url := fmt.Sprintf("%s%s/@v/list", ensureTrailingSlash(baseURL), module)
 
 	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
@@ -36,7 +91,17 @@ func listVersion(module string, verbose bool) ([]string, error) {
 		return nil, err
 	}
 
-	allVersions := strings.Split(strings.TrimSpace(string(body)), "\n")
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return nil, &errModuleNotFound{module: module}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("proxy returned status %d for %s", resp.StatusCode, url)
+	}
+
+	return strings.Split(strings.TrimSpace(string(body)), "\n"), nil
+}
+
+func filterStableVersions(allVersions []string, module string, verbose bool) []string {
 	var stableVersions []string
 
 	for _, v := range allVersions {
@@ -65,38 +130,151 @@ func listVersion(module string, verbose bool) ([]string, error) {
 
 		if len(stableVersions) == 0 && verbose {
 			log.Printf("Using all available versions")
-			return allVersions, nil
+			return allVersions
 		}
 	}
 
-	return stableVersions, nil
+	return stableVersions
 }
 
-func getModuleGoVersion(module, version string, verbose bool) (string, error) {
-	url := fmt.Sprintf(GoproxyCN+"%s/@v/%s.mod", module, version)
+// getModuleGoVersionOnce coalesces concurrent getModuleGoVersion calls for
+// the same module@version (e.g. from different modules in a BatchGet that
+// happen to share a dependency) into a single fetch.
+func (m *Manager) getModuleGoVersionOnce(module, ver string) (string, error) {
+	v, err, _ := m.sf.Do(module+"@"+ver, func() (interface{}, error) {
+		return m.getModuleGoVersion(module, ver)
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+// getModuleGoVersion fetches the go directive from module@version's go.mod,
+// trying each proxy in the Manager's GOPROXY chain in turn with the same
+// fallback rules as listVersion.
+func (m *Manager) getModuleGoVersion(module, version string) (string, error) {
+	if isPrivateModule(module) {
+		return directModuleGoVersion(module, version, proxyDirect)
+	}
+
+	chain := m.proxyChain()
+	var lastErr error
+
+	for _, entry := range chain {
+		goVer, err := moduleGoVersionFrom(module, version, entry.value)
+		if err == nil {
+			return goVer, nil
+		}
+
+		var notFound *errModuleNotFound
+		if errors.As(err, &notFound) {
+			lastErr = err
+			continue
+		}
+
+		if entry.fallThroughAny {
+			lastErr = err
+			continue
+		}
+
+		return "", fmt.Errorf("failed to get go.mod for %s@%s from %s: %w", module, version, entry.value, err)
+	}
+
+	return "", fmt.Errorf("no proxy in GOPROXY chain resolved %s@%s: %w", module, version, lastErr)
+}
+
+func moduleGoVersionFrom(module, version, entry string) (string, error) {
+	switch entry {
+	case proxyDirect, proxyOff:
+		return directModuleGoVersion(module, version, entry)
+	default:
+		return fetchModuleGoVersion(entry, module, version)
+	}
+}
+
+func fetchModuleGoVersion(baseURL, module, version string) (string, error) {
+	body, err := fetchGoModBytes(baseURL, module, version)
 	if err != nil {
 		return "", err
 	}
 
+	goVer, err := goDirective(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse go.mod for %s@%s: %w", module, version, err)
+	}
+
+	return goVer, nil
+}
+
+// fetchGoModBytes fetches the raw go.mod bytes for module@version from a
+// single proxy, used both to parse the go directive and, separately, to
+// verify the module's checksum.
+func fetchGoModBytes(baseURL, module, version string) ([]byte, error) {
+	url := fmt.Sprintf("%s%s/@v/%s.mod", ensureTrailingSlash(baseURL), module, version)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return nil, &errModuleNotFound{module: module, version: version}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("proxy returned status %d for %s", resp.StatusCode, url)
+	}
+
+	return body, nil
+}
+
+// fetchZipBytes fetches the raw module zip bytes for module@version from a
+// single proxy, used to verify the module's checksum.
+func fetchZipBytes(baseURL, module, version string) ([]byte, error) {
+	url := fmt.Sprintf("%s%s/@v/%s.zip", ensureTrailingSlash(baseURL), module, version)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	matches := ModGoVersionPattern.FindStringSubmatch(string(body))
-	if len(matches) > 1 {
-		return matches[1], nil
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	return "", fmt.Errorf("no Go version found in go.mod for %s@%s", module, version)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return nil, &errModuleNotFound{module: module, version: version}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("proxy returned status %d for %s", resp.StatusCode, url)
+	}
+
+	return body, nil
+}
+
+func ensureTrailingSlash(url string) string {
+	if strings.HasSuffix(url, "/") {
+		return url
+	}
+	return url + "/"
 }
 
 func compareGoVersions(currentVersion, requiredVersion string) bool {